@@ -0,0 +1,216 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bigqueryestimatequery implements a tool that dry-runs a query to
+// report its bytes processed/billed, referenced tables and DML row estimate
+// without actually running it.
+package bigqueryestimatequery
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/goccy/go-yaml"
+	bigqueryapi "google.golang.org/api/bigquery/v2"
+
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	bigqueryds "github.com/googleapis/genai-toolbox/internal/sources/bigquery"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+)
+
+const kind string = "bigquery-estimate-query"
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+// Config is the configuration for the bigquery-estimate-query tool.
+type Config struct {
+	Name           string   `yaml:"name" validate:"required"`
+	Kind           string   `yaml:"kind" validate:"required"`
+	Source         string   `yaml:"source" validate:"required"`
+	Description    string   `yaml:"description" validate:"required"`
+	AuthRequired   []string `yaml:"authRequired"`
+	MaxBytesBilled int64    `yaml:"maxBytesBilled"`
+}
+
+// ToolConfigKind returns the kind of tool this config builds.
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+// compatibleSource is the subset of the BigQuery source this tool relies on.
+type compatibleSource interface {
+	BigQueryClient() *bigquery.Client
+	BigQueryRestService() *bigqueryapi.Service
+	BigQueryMaxBytesBilled() int64
+	PricingTier() bigqueryds.PricingTier
+}
+
+// Initialize returns a Tool that dry-runs queries against the configured
+// BigQuery source.
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	s, ok := rawS.(compatibleSource)
+	if !ok {
+		return nil, fmt.Errorf("source %q does not support bigquery-estimate-query", cfg.Source)
+	}
+
+	maxBytesBilled := cfg.MaxBytesBilled
+	if srcMax := s.BigQueryMaxBytesBilled(); srcMax > 0 {
+		if maxBytesBilled > 0 && maxBytesBilled > srcMax {
+			return nil, fmt.Errorf("tool maxBytesBilled (%d) exceeds the stricter limit (%d) configured on source %q", maxBytesBilled, srcMax, cfg.Source)
+		}
+		if cfg.MaxBytesBilled > 0 {
+			maxBytesBilled = cfg.MaxBytesBilled
+		} else {
+			maxBytesBilled = srcMax
+		}
+	}
+
+	allParameters := parameters.Parameters{
+		parameters.NewStringParameter("sql", "The sql to estimate the cost and affected rows of."),
+	}
+
+	mcpManifest := tools.GetMcpManifest(cfg.Name, cfg.Description, cfg.AuthRequired, allParameters)
+
+	return Tool{
+		Name:           cfg.Name,
+		Kind:           kind,
+		AuthRequired:   cfg.AuthRequired,
+		AllParams:      allParameters,
+		projectID:      s.BigQueryClient().Project(),
+		restService:    s.BigQueryRestService(),
+		pricingTier:    s.PricingTier(),
+		maxBytesBilled: maxBytesBilled,
+		manifest:       tools.Manifest{Description: cfg.Description, Parameters: allParameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:    mcpManifest,
+	}, nil
+}
+
+// Tool is the bigquery-estimate-query tool.
+type Tool struct {
+	Name         string `yaml:"name"`
+	Kind         string `yaml:"kind"`
+	AuthRequired []string
+	AllParams    parameters.Parameters
+
+	projectID      string
+	restService    *bigqueryapi.Service
+	pricingTier    bigqueryds.PricingTier
+	maxBytesBilled int64
+	manifest       tools.Manifest
+	mcpManifest    tools.McpManifest
+}
+
+// estimateResult is the structured dry-run estimate returned by Invoke.
+type estimateResult struct {
+	TotalBytesProcessed int64    `json:"totalBytesProcessed"`
+	TotalBytesBilled    int64    `json:"totalBytesBilled"`
+	EstimatedCost       float64  `json:"estimatedCost,omitempty"`
+	CacheHit            bool     `json:"cacheHit"`
+	ReferencedTables    []string `json:"referencedTables"`
+	Schema              []string `json:"schema"`
+	StatementType       string   `json:"statementType"`
+	NumDmlAffectedRows  int64    `json:"numDmlAffectedRows,omitempty"`
+}
+
+// Invoke dry-runs the caller-supplied sql and reports its cost and shape.
+func (t Tool) Invoke(ctx context.Context, params parameters.ParamValues, accessToken string) (any, error) {
+	paramsMap := params.AsMap()
+	sql, ok := paramsMap["sql"].(string)
+	if !ok {
+		return nil, fmt.Errorf("unable to parse sql: expected a string")
+	}
+
+	// maximumBytesBilled is not set here: BigQuery never bills or enforces it
+	// on a dry run, since nothing is actually executed. Instead, the dry
+	// run's reported TotalBytesBilled is checked against t.maxBytesBilled
+	// below, after the estimate comes back.
+	job := &bigqueryapi.Job{
+		Configuration: &bigqueryapi.JobConfiguration{
+			DryRun: true,
+			Query: &bigqueryapi.JobConfigurationQuery{
+				Query: sql,
+			},
+		},
+	}
+
+	insertedJob, err := t.restService.Jobs.Insert(t.projectID, job).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("unable to dry run query: %w", err)
+	}
+
+	stats := insertedJob.Statistics
+	if stats == nil || stats.Query == nil {
+		return nil, fmt.Errorf("dry run returned no query statistics")
+	}
+
+	result := estimateResult{
+		TotalBytesProcessed: stats.Query.TotalBytesProcessed,
+		TotalBytesBilled:    stats.Query.TotalBytesBilled,
+		CacheHit:            stats.Query.CacheHit,
+		StatementType:       stats.Query.StatementType,
+		NumDmlAffectedRows:  stats.Query.NumDmlAffectedRows,
+	}
+	if t.pricingTier.BytesPerUnit > 0 {
+		result.EstimatedCost = float64(result.TotalBytesBilled) / float64(t.pricingTier.BytesPerUnit) * t.pricingTier.PricePerUnit
+	}
+	for _, table := range stats.Query.ReferencedTables {
+		result.ReferencedTables = append(result.ReferencedTables, fmt.Sprintf("%s.%s.%s", table.ProjectId, table.DatasetId, table.TableId))
+	}
+	if stats.Query.Schema != nil {
+		for _, f := range stats.Query.Schema.Fields {
+			result.Schema = append(result.Schema, fmt.Sprintf("%s:%s:%s", f.Name, f.Type, f.Mode))
+		}
+	}
+
+	if t.maxBytesBilled > 0 && result.TotalBytesBilled > t.maxBytesBilled {
+		return nil, fmt.Errorf("query would bill %d bytes, exceeding the configured maxBytesBilled (%d)", result.TotalBytesBilled, t.maxBytesBilled)
+	}
+
+	return result, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claimsMap map[string]map[string]any) (parameters.ParamValues, error) {
+	return tools.ParseParams(t.AllParams, data, claimsMap)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
@@ -0,0 +1,226 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigqueryestimatequery_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/bigquery"
+	bq "google.golang.org/api/bigquery/v2"
+	"google.golang.org/api/option"
+	http_testing "google.golang.org/api/transport/http/testing"
+
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	bigqueryds "github.com/googleapis/genai-toolbox/internal/sources/bigquery"
+	"github.com/googleapis/genai-toolbox/internal/tools/bigquery/bigqueryestimatequery"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+)
+
+type mockBigQuerySource struct {
+	sources.Source
+	client         *bigquery.Client
+	restService    *bq.Service
+	maxBytesBilled int64
+	pricingTier    bigqueryds.PricingTier
+}
+
+func (s *mockBigQuerySource) BigQueryClient() *bigquery.Client    { return s.client }
+func (s *mockBigQuerySource) BigQueryRestService() *bq.Service    { return s.restService }
+func (s *mockBigQuerySource) BigQueryMaxBytesBilled() int64       { return s.maxBytesBilled }
+func (s *mockBigQuerySource) PricingTier() bigqueryds.PricingTier { return s.pricingTier }
+
+func newMockSource(t *testing.T, body string, maxBytesBilled int64) *mockBigQuerySource {
+	t.Helper()
+	mockTransport, err := http_testing.NewRoundTripper(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}, nil
+	})
+	if err != nil {
+		t.Fatalf("http_testing.NewRoundTripper: %v", err)
+	}
+	client, err := bigquery.NewClient(context.Background(), "test-project", option.WithHTTPClient(&http.Client{Transport: mockTransport}))
+	if err != nil {
+		t.Fatalf("bigquery.NewClient: %v", err)
+	}
+	restService, err := bq.NewService(context.Background(), option.WithHTTPClient(&http.Client{Transport: mockTransport}))
+	if err != nil {
+		t.Fatalf("bq.NewService: %v", err)
+	}
+	return &mockBigQuerySource{
+		client:         client,
+		restService:    restService,
+		maxBytesBilled: maxBytesBilled,
+		pricingTier:    bigqueryds.PricingTier{BytesPerUnit: 1 << 40, PricePerUnit: 5},
+	}
+}
+
+// jobResponse builds the dry-run jobs.insert response body BigQuery would
+// return for a query billing totalBytesBilled bytes.
+func jobResponse(totalBytesBilled int64) string {
+	return `{
+		"jobReference": {"projectId":"test-project","jobId":"job1","location":"US"},
+		"status": {"state":"DONE"},
+		"statistics": {"query": {
+			"totalBytesProcessed": "` + itoa(totalBytesBilled) + `",
+			"totalBytesBilled": "` + itoa(totalBytesBilled) + `",
+			"statementType": "SELECT"
+		}}
+	}`
+}
+
+func itoa(n int64) string {
+	if n == 0 {
+		return "0"
+	}
+	neg := n < 0
+	if neg {
+		n = -n
+	}
+	var digits []byte
+	for n > 0 {
+		digits = append([]byte{byte('0' + n%10)}, digits...)
+		n /= 10
+	}
+	if neg {
+		digits = append([]byte{'-'}, digits...)
+	}
+	return string(digits)
+}
+
+func TestInitialize(t *testing.T) {
+	tcs := []struct {
+		name              string
+		toolMaxBytes      int64
+		sourceMaxBytes    int64
+		wantErr           bool
+		wantResolvedBytes int64
+	}{
+		{
+			name:              "no caps configured",
+			wantResolvedBytes: 0,
+		},
+		{
+			name:              "tool cap only",
+			toolMaxBytes:      100,
+			wantResolvedBytes: 100,
+		},
+		{
+			name:              "source cap only",
+			sourceMaxBytes:    1000,
+			wantResolvedBytes: 1000,
+		},
+		{
+			name:              "tool cap stricter than source cap is kept, not discarded",
+			toolMaxBytes:      100,
+			sourceMaxBytes:    1000,
+			wantResolvedBytes: 100,
+		},
+		{
+			name:           "tool cap looser than source cap is rejected",
+			toolMaxBytes:   1000,
+			sourceMaxBytes: 100,
+			wantErr:        true,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			// The dry run bills one byte over the resolved cap, so Invoke fails
+			// exactly when a cap was resolved.
+			src := newMockSource(t, jobResponse(tc.wantResolvedBytes+1), tc.sourceMaxBytes)
+			defer src.client.Close()
+
+			srcs := map[string]sources.Source{"my-instance": src}
+			cfg := bigqueryestimatequery.Config{
+				Name:           "test-tool",
+				Source:         "my-instance",
+				Description:    "test tool",
+				MaxBytesBilled: tc.toolMaxBytes,
+			}
+			tool, err := cfg.Initialize(srcs)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Initialize() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+
+			params := parameters.ParamValues{parameters.ParamValue{Name: "sql", Value: "SELECT 1"}}
+			_, err = tool.Invoke(context.Background(), params, "")
+			if wantInvokeErr := tc.wantResolvedBytes > 0; (err != nil) != wantInvokeErr {
+				t.Fatalf("Invoke() billing %d bytes: error = %v, wantErr %v", tc.wantResolvedBytes+1, err, wantInvokeErr)
+			}
+		})
+	}
+}
+
+func TestInvoke(t *testing.T) {
+	tcs := []struct {
+		name             string
+		maxBytesBilled   int64
+		totalBytesBilled int64
+		expectedErr      bool
+	}{
+		{
+			name:             "no cap configured",
+			totalBytesBilled: 5000,
+			expectedErr:      false,
+		},
+		{
+			name:             "estimate within the configured cap",
+			maxBytesBilled:   10000,
+			totalBytesBilled: 5000,
+			expectedErr:      false,
+		},
+		{
+			name:             "estimate exceeding the configured cap is rejected",
+			maxBytesBilled:   1000,
+			totalBytesBilled: 5000,
+			expectedErr:      true,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			src := newMockSource(t, jobResponse(tc.totalBytesBilled), 0)
+			defer src.client.Close()
+
+			srcs := map[string]sources.Source{"my-instance": src}
+			cfg := bigqueryestimatequery.Config{
+				Name:           "test-tool",
+				Source:         "my-instance",
+				Description:    "test tool",
+				MaxBytesBilled: tc.maxBytesBilled,
+			}
+			tool, err := cfg.Initialize(srcs)
+			if err != nil {
+				t.Fatalf("Initialize() error = %v", err)
+			}
+
+			params := parameters.ParamValues{parameters.ParamValue{Name: "sql", Value: "SELECT 1"}}
+			_, err = tool.Invoke(context.Background(), params, "")
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("Invoke() error = %v, wantErr %v", err, tc.expectedErr)
+			}
+		})
+	}
+}
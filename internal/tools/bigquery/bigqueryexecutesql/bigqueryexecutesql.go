@@ -0,0 +1,421 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bigqueryexecutesql implements a tool that runs arbitrary,
+// caller-supplied SQL against a BigQuery source via jobs.query.
+package bigqueryexecutesql
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/goccy/go-yaml"
+	bigqueryapi "google.golang.org/api/bigquery/v2"
+	"google.golang.org/api/iterator"
+
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	bigqueryds "github.com/googleapis/genai-toolbox/internal/sources/bigquery"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+)
+
+const kind string = "bigquery-execute-sql"
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return &actual, nil
+}
+
+// Config is the configuration for the bigquery-execute-sql tool.
+type Config struct {
+	Name               string   `yaml:"name" validate:"required"`
+	Kind               string   `yaml:"kind" validate:"required"`
+	Source             string   `yaml:"source" validate:"required"`
+	Description        string   `yaml:"description" validate:"required"`
+	AuthRequired       []string `yaml:"authRequired"`
+	MaxQueryResultRows int      `yaml:"maxQueryResultRows"`
+}
+
+// ToolConfigKind returns the kind of tool this config builds.
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+// compatibleSource is the subset of the BigQuery source this tool relies on.
+type compatibleSource interface {
+	BigQueryClient() *bigquery.Client
+	BigQuerySession() bigqueryds.BigQuerySessionProvider
+	BigQueryWriteMode() string
+	BigQueryRestService() *bigqueryapi.Service
+	BigQueryClientCreator() bigqueryds.BigqueryClientCreator
+	UseClientAuthorization() bool
+	IsDatasetAllowed(projectID, datasetID string) bool
+	GetMaxQueryResultRows() int
+}
+
+// Initialize returns a Tool that executes arbitrary SQL against the
+// configured BigQuery source.
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	s, ok := rawS.(compatibleSource)
+	if !ok {
+		return nil, fmt.Errorf("source %q does not support bigquery-execute-sql", cfg.Source)
+	}
+
+	// A source that also reports a row limit caps the tool's own limit.
+	maxQueryResultRows := cfg.MaxQueryResultRows
+	if ms, ok := rawS.(interface{ GetMaxQueryResultRows() int }); ok {
+		if srcMax := ms.GetMaxQueryResultRows(); srcMax > 0 && (maxQueryResultRows <= 0 || srcMax < maxQueryResultRows) {
+			maxQueryResultRows = srcMax
+		}
+	}
+
+	allParameters := parameters.Parameters{
+		parameters.NewStringParameter("sql", "The sql to execute."),
+		parameters.NewBooleanParameterWithDefault("dry_run", false, "If true, validates the query and returns statistics without running it."),
+		parameters.NewBooleanParameterWithDefault("scriptMode", false, "If true, runs sql as a multi-statement BigQuery script via jobs.insert, returning one result set per statement."),
+		parameters.NewArrayParameterWithDefault("params", []any{}, "Positional (?) or named (@name) query parameters to bind into sql.",
+			parameters.NewMapParameter("", "A single query parameter.", "string")),
+	}
+
+	mcpManifest := tools.GetMcpManifest(cfg.Name, cfg.Description, cfg.AuthRequired, allParameters)
+
+	return Tool{
+		Name:               cfg.Name,
+		Kind:               kind,
+		AuthRequired:       cfg.AuthRequired,
+		client:             s.BigQueryClient(),
+		session:            s.BigQuerySession(),
+		writeMode:          s.BigQueryWriteMode(),
+		maxQueryResultRows: maxQueryResultRows,
+		AllParams:          allParameters,
+		manifest:           tools.Manifest{Description: cfg.Description, Parameters: allParameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:        mcpManifest,
+	}, nil
+}
+
+// Tool is the bigquery-execute-sql tool.
+type Tool struct {
+	Name         string `yaml:"name"`
+	Kind         string `yaml:"kind"`
+	AuthRequired []string
+	AllParams    parameters.Parameters
+
+	client             *bigquery.Client
+	session            bigqueryds.BigQuerySessionProvider
+	writeMode          string
+	maxQueryResultRows int
+	manifest           tools.Manifest
+	mcpManifest        tools.McpManifest
+}
+
+// queryParam is a single user-supplied BigQuery query parameter, either
+// positional (Name == "") or named.
+type queryParam struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Value any    `json:"value"`
+}
+
+// Invoke runs the caller-supplied sql, optionally as a dry run, a
+// parameterized query, or a multi-statement script.
+func (t Tool) Invoke(ctx context.Context, params parameters.ParamValues, accessToken string) (any, error) {
+	paramsMap := params.AsMap()
+
+	sql, ok := paramsMap["sql"].(string)
+	if !ok {
+		return nil, fmt.Errorf("unable to parse sql: expected a string")
+	}
+
+	dryRun, _ := paramsMap["dry_run"].(bool)
+	scriptMode, _ := paramsMap["scriptMode"].(bool)
+
+	queryParams, err := parseQueryParams(paramsMap["params"])
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse params: %w", err)
+	}
+	if err := validatePlaceholderStyle(sql, queryParams); err != nil {
+		return nil, err
+	}
+
+	if scriptMode {
+		return t.invokeScript(ctx, sql, queryParams, dryRun)
+	}
+
+	bqParams, err := toBigQueryParameters(queryParams)
+	if err != nil {
+		return nil, fmt.Errorf("unable to bind params: %w", err)
+	}
+
+	query := t.client.Query(sql)
+	query.DryRun = dryRun
+	query.Parameters = bqParams
+	if t.session != nil {
+		query.ConnectionProperties = t.session.ConnectionProperties()
+	}
+
+	job, err := query.Run(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to execute query: %w", err)
+	}
+
+	if dryRun {
+		return []any{}, nil
+	}
+
+	it, err := job.Read(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read query results: %w", err)
+	}
+
+	return readRows(it, t.maxQueryResultRows)
+}
+
+// invokeScript runs sql as a BigQuery script via jobs.insert, returning the
+// result set produced by each child statement. It reuses the source's
+// session provider so state set by DECLARE/SET/CREATE TEMP TABLE statements
+// persists across Invoke calls within the same session. When dryRun is set,
+// the script is validated but never actually run, matching the non-script
+// dry_run behavior.
+func (t Tool) invokeScript(ctx context.Context, sql string, queryParams []queryParam, dryRun bool) (any, error) {
+	bqParams, err := toBigQueryParameters(queryParams)
+	if err != nil {
+		return nil, fmt.Errorf("unable to bind params: %w", err)
+	}
+
+	query := t.client.Query(sql)
+	query.DryRun = dryRun
+	query.Parameters = bqParams
+	if t.session != nil {
+		query.ConnectionProperties = t.session.ConnectionProperties()
+	}
+
+	job, err := query.Run(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to execute script: %w", err)
+	}
+
+	if dryRun {
+		return []any{}, nil
+	}
+
+	status, err := job.Wait(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to wait for script: %w", err)
+	}
+	if err := status.Err(); err != nil {
+		return nil, fmt.Errorf("script failed: %w", err)
+	}
+
+	children, err := t.client.JobFromProject(ctx, job.ProjectID(), job.ID(), job.Location())
+	if err != nil {
+		return nil, fmt.Errorf("unable to look up script job: %w", err)
+	}
+
+	childIt := children.Children(ctx)
+	results := []any{}
+	for {
+		childJob, err := childIt.Next()
+		if err != nil {
+			break
+		}
+		it, err := childJob.Read(ctx)
+		if err != nil {
+			continue
+		}
+		rows, err := readRows(it, t.maxQueryResultRows)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, rows)
+	}
+	return results, nil
+}
+
+func readRows(it *bigquery.RowIterator, maxRows int) ([]any, error) {
+	var rows []any
+	for {
+		var row map[string]bigquery.Value
+		err := it.Next(&row)
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse row: %w", err)
+		}
+		rows = append(rows, row)
+		if maxRows > 0 && len(rows) >= maxRows {
+			break
+		}
+	}
+	return rows, nil
+}
+
+// validatePlaceholderStyle rejects sql that mixes positional (?) and named
+// (@name) placeholders, or that declares params not used in either style.
+func validatePlaceholderStyle(sql string, queryParams []queryParam) error {
+	hasPositional := false
+	hasNamed := false
+	for _, p := range queryParams {
+		if p.Name == "" {
+			hasPositional = true
+		} else {
+			hasNamed = true
+		}
+	}
+	if hasPositional && hasNamed {
+		return fmt.Errorf("params must be either all positional (?) or all named (@name), not both")
+	}
+	if hasPositional && !strings.Contains(sql, "?") {
+		return fmt.Errorf("positional params were supplied but sql contains no '?' placeholders")
+	}
+	if hasNamed && strings.Contains(sql, "?") {
+		return fmt.Errorf("named params were supplied but sql also contains '?' placeholders")
+	}
+	// "@@name" is a system variable, not a named placeholder.
+	if hasPositional && regexp.MustCompile(`(^|[^@])@[A-Za-z_]`).MatchString(sql) {
+		return fmt.Errorf("positional params were supplied but sql also contains '@name' placeholders")
+	}
+	for _, p := range queryParams {
+		if p.Name != "" && !strings.Contains(sql, "@"+p.Name) {
+			return fmt.Errorf("named param %q was supplied but sql contains no '@%s' placeholder", p.Name, p.Name)
+		}
+	}
+	return nil
+}
+
+func parseQueryParams(raw any) ([]queryParam, error) {
+	if raw == nil {
+		return nil, nil
+	}
+	rawList, ok := raw.([]any)
+	if !ok {
+		return nil, fmt.Errorf("expected an array")
+	}
+	out := make([]queryParam, 0, len(rawList))
+	for _, rawItem := range rawList {
+		m, ok := rawItem.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("expected each param to be an object with name, type and value")
+		}
+		p := queryParam{Value: m["value"]}
+		if name, ok := m["name"].(string); ok {
+			p.Name = name
+		}
+		if typ, ok := m["type"].(string); ok {
+			p.Type = typ
+		}
+		out = append(out, p)
+	}
+	return out, nil
+}
+
+func toBigQueryParameters(queryParams []queryParam) ([]bigquery.QueryParameter, error) {
+	out := make([]bigquery.QueryParameter, 0, len(queryParams))
+	for _, p := range queryParams {
+		value, err := coerceParamValue(p)
+		if err != nil {
+			return nil, fmt.Errorf("param %q: %w", p.Name, err)
+		}
+		out = append(out, bigquery.QueryParameter{Name: p.Name, Value: value})
+	}
+	return out, nil
+}
+
+// coerceParamValue converts a caller-supplied param into the Go value
+// bigquery.QueryParameter expects for the declared BQ type. Values decoded
+// from JSON/YAML are ambiguous on their own (e.g. a JSON number always
+// decodes to float64, even when the caller declared INT64), so the declared
+// type tag - not the dynamic type of the decoded value - decides the
+// conversion.
+func coerceParamValue(p queryParam) (any, error) {
+	if p.Type == "" {
+		return p.Value, nil
+	}
+	switch strings.ToUpper(p.Type) {
+	case "INT64", "INTEGER":
+		switch v := p.Value.(type) {
+		case float64:
+			return int64(v), nil
+		case int64:
+			return v, nil
+		case string:
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid INT64 value %v: %w", p.Value, err)
+			}
+			return n, nil
+		default:
+			return nil, fmt.Errorf("invalid INT64 value %v", p.Value)
+		}
+	case "FLOAT64", "FLOAT":
+		switch v := p.Value.(type) {
+		case float64:
+			return v, nil
+		case string:
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid FLOAT64 value %v: %w", p.Value, err)
+			}
+			return f, nil
+		default:
+			return nil, fmt.Errorf("invalid FLOAT64 value %v", p.Value)
+		}
+	case "BOOL", "BOOLEAN":
+		if v, ok := p.Value.(bool); ok {
+			return v, nil
+		}
+		return nil, fmt.Errorf("invalid BOOL value %v", p.Value)
+	case "STRING", "DATE", "DATETIME", "TIME", "TIMESTAMP", "NUMERIC", "BIGNUMERIC":
+		if v, ok := p.Value.(string); ok {
+			return v, nil
+		}
+		return nil, fmt.Errorf("invalid %s value %v: expected a string", p.Type, p.Value)
+	default:
+		return nil, fmt.Errorf("unsupported param type %q", p.Type)
+	}
+}
+
+func (t Tool) ParseParams(data map[string]any, claimsMap map[string]map[string]any) (parameters.ParamValues, error) {
+	return tools.ParseParams(t.AllParams, data, claimsMap)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
@@ -16,8 +16,12 @@ package bigqueryexecutesql_test
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"cloud.google.com/go/bigquery"
 	"github.com/goccy/go-yaml"
@@ -30,7 +34,7 @@ import (
 	"github.com/googleapis/genai-toolbox/internal/tools/bigquery/bigqueryexecutesql"
 	"github.com/googleapis/genai-toolbox/internal/util/parameters"
 	"google.golang.org/api/option"
-	"google.golang.org/api/transport/http/testing as http_testing"
+	http_testing "google.golang.org/api/transport/http/testing"
 
 	bq "google.golang.org/api/bigquery/v2"
 )
@@ -121,8 +125,18 @@ func (s *mockBigQuerySource) BigQueryAllowedDatasets() []string
 func TestInvoke(t *testing.T) {
 	ctx := context.Background()
 
-	// Create a mock HTTP transport.
+	// Create a mock HTTP transport. jobs.get (a single job by ID, as polled by
+	// Job.Wait) reports the job as DONE immediately so no case can poll
+	// forever against a mock that otherwise never advances job state.
 	mockTransport, err := http_testing.NewRoundTripper(func(req *http.Request) (*http.Response, error) {
+		if req.Method == http.MethodGet && strings.Contains(req.URL.Path, "/jobs/") {
+			body := `{"status":{"state":"DONE"},"jobReference":{"projectId":"test-project","jobId":"script-job","location":"US"}}`
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(body)),
+			}, nil
+		}
 		return &http.Response{
 			StatusCode: http.StatusOK,
 			Body:       http.NoBody,
@@ -178,6 +192,23 @@ func TestInvoke(t *testing.T) {
 			expectedNumRows: 2,
 			expectedErr:     false,
 		},
+		{
+			name: "with named query parameter",
+			config: &bigqueryexecutesql.Config{
+				Name:        "test-tool",
+				Source:      "my-instance",
+				Description: "test tool",
+			},
+			params: parameters.ParamValues{
+				parameters.ParamValue{Name: "sql", Value: "SELECT @min_value"},
+				parameters.ParamValue{Name: "dry_run", Value: true},
+				parameters.ParamValue{Name: "params", Value: []any{
+					map[string]any{"name": "min_value", "type": "INT64", "value": int64(1)},
+				}},
+			},
+			expectedNumRows: 0,
+			expectedErr:     false,
+		},
 	}
 
 	for _, tc := range tcs {
@@ -187,7 +218,10 @@ func TestInvoke(t *testing.T) {
 				t.Fatalf("Initialize() error = %v", err)
 			}
 
-			result, err := tool.Invoke(ctx, tc.params, "")
+			invokeCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+			defer cancel()
+
+			result, err := tool.Invoke(invokeCtx, tc.params, "")
 			if (err != nil) != tc.expectedErr {
 				t.Fatalf("Invoke() error = %v, wantErr %v", err, tc.expectedErr)
 			}
@@ -204,3 +238,170 @@ func TestInvoke(t *testing.T) {
 		})
 	}
 }
+
+// scriptJobsResponse serves a fake BigQuery backend for a two-statement
+// script: jobs.insert submits the script job, jobs.get polls it to DONE,
+// jobs.list (with parentJobId) lists its two child statement jobs, and
+// jobs.getQueryResults (the /queries/ path) returns each child's own single
+// row, so the test can assert on two distinct result sets rather than just
+// "didn't hang".
+func scriptJobsResponse(t *testing.T, req *http.Request) (*http.Response, error) {
+	t.Helper()
+
+	jsonResponse := func(body string) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}, nil
+	}
+
+	switch {
+	case req.Method == http.MethodGet && strings.Contains(req.URL.Path, "/queries/child-job-1"):
+		return jsonResponse(`{"jobComplete":true,"schema":{"fields":[{"name":"f0_","type":"INTEGER","mode":"NULLABLE"}]},"rows":[{"f":[{"v":"1"}]}]}`)
+	case req.Method == http.MethodGet && strings.Contains(req.URL.Path, "/queries/child-job-2"):
+		return jsonResponse(`{"jobComplete":true,"schema":{"fields":[{"name":"f0_","type":"INTEGER","mode":"NULLABLE"}]},"rows":[{"f":[{"v":"2"}]}]}`)
+	case req.Method == http.MethodGet && strings.HasSuffix(req.URL.Path, "/jobs") && req.URL.Query().Get("parentJobId") == "script-job":
+		return jsonResponse(`{"jobs":[
+			{"id":"test-project:US.child-job-1","jobReference":{"projectId":"test-project","jobId":"child-job-1","location":"US"},"state":"DONE","status":{"state":"DONE"}},
+			{"id":"test-project:US.child-job-2","jobReference":{"projectId":"test-project","jobId":"child-job-2","location":"US"},"state":"DONE","status":{"state":"DONE"}}
+		]}`)
+	case req.Method == http.MethodGet && strings.Contains(req.URL.Path, "/jobs/"):
+		return jsonResponse(`{"status":{"state":"DONE"},"jobReference":{"projectId":"test-project","jobId":"script-job","location":"US"}}`)
+	default:
+		return jsonResponse(`{"status":{"state":"DONE"},"jobReference":{"projectId":"test-project","jobId":"script-job","location":"US"}}`)
+	}
+}
+
+func TestInvokeScriptMode(t *testing.T) {
+	ctx := context.Background()
+
+	mockTransport, err := http_testing.NewRoundTripper(func(req *http.Request) (*http.Response, error) {
+		return scriptJobsResponse(t, req)
+	})
+	if err != nil {
+		t.Fatalf("http_testing.NewRoundTripper: %v", err)
+	}
+
+	client, err := bigquery.NewClient(ctx, "test-project", option.WithHTTPClient(&http.Client{Transport: mockTransport}))
+	if err != nil {
+		t.Fatalf("bigquery.NewClient: %v", err)
+	}
+	defer client.Close()
+
+	restService, err := bq.NewService(ctx, option.WithHTTPClient(&http.Client{Transport: mockTransport}))
+	if err != nil {
+		t.Fatalf("bq.NewService: %v", err)
+	}
+
+	srcs := map[string]sources.Source{
+		"my-instance": &mockBigQuerySource{client: client, restService: restService},
+	}
+
+	cfg := &bigqueryexecutesql.Config{
+		Name:        "test-tool",
+		Source:      "my-instance",
+		Description: "test tool",
+	}
+	tool, err := cfg.Initialize(srcs)
+	if err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+
+	invokeCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	params := parameters.ParamValues{
+		parameters.ParamValue{Name: "sql", Value: "SELECT 1; SELECT 2;"},
+		parameters.ParamValue{Name: "scriptMode", Value: true},
+	}
+	result, err := tool.Invoke(invokeCtx, params, "")
+	if err != nil {
+		t.Fatalf("Invoke() error = %v", err)
+	}
+
+	resultSets, ok := result.([]any)
+	if !ok {
+		t.Fatalf("Invoke() returned non-result-sets result: %T", result)
+	}
+	if len(resultSets) != 2 {
+		t.Fatalf("Invoke() returned %d result sets, want 2", len(resultSets))
+	}
+
+	for i, want := range []string{"1", "2"} {
+		rows, ok := resultSets[i].([]any)
+		if !ok || len(rows) != 1 {
+			t.Fatalf("result set %d = %v, want a single row", i, resultSets[i])
+		}
+		row, ok := rows[0].(map[string]bigquery.Value)
+		if !ok {
+			t.Fatalf("result set %d row = %T, want map[string]bigquery.Value", i, rows[0])
+		}
+		got := fmt.Sprint(row["f0_"])
+		if got != want {
+			t.Errorf("result set %d row = %v, want f0_ = %s", i, row, want)
+		}
+	}
+}
+
+func TestInvokeScriptModeDryRun(t *testing.T) {
+	ctx := context.Background()
+
+	// Any GET request would mean the script was actually polled/executed
+	// past query.Run, which a dry run must never do.
+	mockTransport, err := http_testing.NewRoundTripper(func(req *http.Request) (*http.Response, error) {
+		if req.Method == http.MethodGet {
+			t.Fatalf("unexpected GET %s: a script dry run must not poll or look up jobs", req.URL.Path)
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"status":{"state":"DONE"},"jobReference":{"projectId":"test-project","jobId":"script-job","location":"US"}}`)),
+		}, nil
+	})
+	if err != nil {
+		t.Fatalf("http_testing.NewRoundTripper: %v", err)
+	}
+
+	client, err := bigquery.NewClient(ctx, "test-project", option.WithHTTPClient(&http.Client{Transport: mockTransport}))
+	if err != nil {
+		t.Fatalf("bigquery.NewClient: %v", err)
+	}
+	defer client.Close()
+
+	restService, err := bq.NewService(ctx, option.WithHTTPClient(&http.Client{Transport: mockTransport}))
+	if err != nil {
+		t.Fatalf("bq.NewService: %v", err)
+	}
+
+	srcs := map[string]sources.Source{
+		"my-instance": &mockBigQuerySource{client: client, restService: restService},
+	}
+
+	cfg := &bigqueryexecutesql.Config{
+		Name:        "test-tool",
+		Source:      "my-instance",
+		Description: "test tool",
+	}
+	tool, err := cfg.Initialize(srcs)
+	if err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+
+	invokeCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	params := parameters.ParamValues{
+		parameters.ParamValue{Name: "sql", Value: "SELECT 1; SELECT 2;"},
+		parameters.ParamValue{Name: "scriptMode", Value: true},
+		parameters.ParamValue{Name: "dry_run", Value: true},
+	}
+	result, err := tool.Invoke(invokeCtx, params, "")
+	if err != nil {
+		t.Fatalf("Invoke() error = %v", err)
+	}
+	rows, ok := result.([]any)
+	if !ok || len(rows) != 0 {
+		t.Fatalf("Invoke() = %v, want an empty result", result)
+	}
+}
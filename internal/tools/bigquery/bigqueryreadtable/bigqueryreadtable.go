@@ -0,0 +1,326 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bigqueryreadtable implements a tool that reads rows directly out
+// of a BigQuery table via the Storage Read API, bypassing jobs.query. Unlike
+// bigqueryexecutesql, which always pays for and waits on a query job, this
+// tool streams the table's data directly and preserves the table's schema
+// (including REQUIRED vs NULLABLE field modes) in its response.
+package bigqueryreadtable
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"cloud.google.com/go/bigquery"
+	storage "cloud.google.com/go/bigquery/storage/apiv1"
+	"cloud.google.com/go/bigquery/storage/apiv1/storagepb"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/goccy/go-yaml"
+	bigqueryapi "google.golang.org/api/bigquery/v2"
+	"google.golang.org/api/option"
+
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+)
+
+const kind string = "bigquery-read-table"
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+// Config is the configuration for the bigquery-read-table tool.
+type Config struct {
+	Name         string   `yaml:"name" validate:"required"`
+	Kind         string   `yaml:"kind" validate:"required"`
+	Source       string   `yaml:"source" validate:"required"`
+	Description  string   `yaml:"description" validate:"required"`
+	AuthRequired []string `yaml:"authRequired"`
+
+	Project        string   `yaml:"project" validate:"required"`
+	Dataset        string   `yaml:"dataset" validate:"required"`
+	Table          string   `yaml:"table" validate:"required"`
+	SelectedFields []string `yaml:"selectedFields"`
+	RowRestriction string   `yaml:"rowRestriction"`
+	MaxRows        int64    `yaml:"maxRows"`
+}
+
+// ToolConfigKind returns the kind of tool this config builds.
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+// compatibleSource is the subset of the BigQuery source this tool relies on.
+type compatibleSource interface {
+	BigQueryClient() *bigquery.Client
+	BigQueryRestService() *bigqueryapi.Service
+	GetMaxQueryResultRows() int
+	IsDatasetAllowed(projectID, datasetID string) bool
+}
+
+// Initialize returns a Tool that reads rows from a single table using the
+// BigQuery Storage Read API.
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	s, ok := rawS.(compatibleSource)
+	if !ok {
+		return nil, fmt.Errorf("source %q does not support bigquery-read-table", cfg.Source)
+	}
+
+	if !s.IsDatasetAllowed(cfg.Project, cfg.Dataset) {
+		return nil, fmt.Errorf("dataset %q.%q is not allowed by source %q", cfg.Project, cfg.Dataset, cfg.Source)
+	}
+
+	allParameters := parameters.Parameters{}
+
+	mcpManifest := tools.GetMcpManifest(cfg.Name, cfg.Description, cfg.AuthRequired, allParameters)
+
+	return Tool{
+		Name:               cfg.Name,
+		Kind:               kind,
+		AuthRequired:       cfg.AuthRequired,
+		Project:            cfg.Project,
+		Dataset:            cfg.Dataset,
+		Table:              cfg.Table,
+		SelectedFields:     cfg.SelectedFields,
+		RowRestriction:     cfg.RowRestriction,
+		MaxRows:            cfg.MaxRows,
+		client:             s.BigQueryClient(),
+		restService:        s.BigQueryRestService(),
+		maxQueryResultRows: s.GetMaxQueryResultRows(),
+		newReadClient:      storage.NewBigQueryReadClient,
+		manifest:           tools.Manifest{Description: cfg.Description, Parameters: allParameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:        mcpManifest,
+	}, nil
+}
+
+// Tool is the bigquery-read-table tool.
+type Tool struct {
+	Name         string `yaml:"name"`
+	Kind         string `yaml:"kind"`
+	AuthRequired []string
+
+	Project        string
+	Dataset        string
+	Table          string
+	SelectedFields []string
+	RowRestriction string
+	MaxRows        int64
+
+	client             *bigquery.Client
+	restService        *bigqueryapi.Service
+	maxQueryResultRows int
+	// newReadClient constructs the Storage Read API client Invoke reads
+	// through. It's a seam for tests to point at a fake gRPC server instead
+	// of the real Storage Read API; production Initialize always sets it to
+	// storage.NewBigQueryReadClient.
+	newReadClient func(ctx context.Context, opts ...option.ClientOption) (*storage.BigQueryReadClient, error)
+	manifest      tools.Manifest
+	mcpManifest   tools.McpManifest
+}
+
+// readTableResult is the shape returned by Invoke: the rows read from the
+// table plus the schema so callers can tell REQUIRED fields from NULLABLE
+// ones, which a jobs.query-backed tool cannot expose.
+type readTableResult struct {
+	Schema []fieldSchema    `json:"schema"`
+	Rows   []map[string]any `json:"rows"`
+}
+
+type fieldSchema struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Mode string `json:"mode"`
+}
+
+// Invoke streams rows from the configured table via the Storage Read API.
+func (t Tool) Invoke(ctx context.Context, params parameters.ParamValues, accessToken string) (any, error) {
+	table, err := t.restService.Tables.Get(t.Project, t.Dataset, t.Table).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up table schema: %w", err)
+	}
+
+	schema := make([]fieldSchema, 0, len(table.Schema.Fields))
+	for _, f := range table.Schema.Fields {
+		schema = append(schema, fieldSchema{Name: f.Name, Type: f.Type, Mode: f.Mode})
+	}
+
+	readClient, err := t.newReadClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create storage read client: %w", err)
+	}
+	defer readClient.Close()
+
+	maxRows := t.MaxRows
+	if t.maxQueryResultRows > 0 && (maxRows <= 0 || int64(t.maxQueryResultRows) < maxRows) {
+		maxRows = int64(t.maxQueryResultRows)
+	}
+
+	session, err := readClient.CreateReadSession(ctx, &storagepb.CreateReadSessionRequest{
+		Parent: fmt.Sprintf("projects/%s", t.Project),
+		ReadSession: &storagepb.ReadSession{
+			Table:      fmt.Sprintf("projects/%s/datasets/%s/tables/%s", t.Project, t.Dataset, t.Table),
+			DataFormat: storagepb.DataFormat_ARROW,
+			ReadOptions: &storagepb.ReadSession_TableReadOptions{
+				SelectedFields: t.SelectedFields,
+				RowRestriction: t.RowRestriction,
+			},
+		},
+		MaxStreamCount: 1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create read session: %w", err)
+	}
+
+	// The stream only carries the columns actually selected, in selection
+	// order, which may narrow or reorder the table's full schema.
+	streamSchema, err := resolveStreamSchema(schema, t.SelectedFields)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := []map[string]any{}
+	if len(session.GetStreams()) > 0 {
+		rows, err = readRowsFromStream(ctx, readClient, session.GetStreams()[0].GetName(), streamSchema, maxRows)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return readTableResult{Schema: schema, Rows: rows}, nil
+}
+
+// resolveStreamSchema returns the schema in the order the Storage Read API
+// stream will actually return columns in. When selectedFields narrows or
+// reorders the table's columns, the stream only carries those columns, in
+// that order, so the full table schema can't be zipped against it directly.
+func resolveStreamSchema(schema []fieldSchema, selectedFields []string) ([]fieldSchema, error) {
+	if len(selectedFields) == 0 {
+		return schema, nil
+	}
+	byName := make(map[string]fieldSchema, len(schema))
+	for _, f := range schema {
+		byName[f.Name] = f
+	}
+	streamSchema := make([]fieldSchema, 0, len(selectedFields))
+	for _, name := range selectedFields {
+		f, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("selected field %q not found in table schema", name)
+		}
+		streamSchema = append(streamSchema, f)
+	}
+	return streamSchema, nil
+}
+
+// readRowsFromStream decodes the Arrow record batches off a single Storage
+// Read API stream into plain maps, stopping once maxRows rows (0 = no limit)
+// have been collected.
+func readRowsFromStream(ctx context.Context, client *storage.BigQueryReadClient, streamName string, schema []fieldSchema, maxRows int64) ([]map[string]any, error) {
+	rowStream, err := client.ReadRows(ctx, &storagepb.ReadRowsRequest{ReadStream: streamName})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open row stream: %w", err)
+	}
+
+	rows := []map[string]any{}
+	for {
+		resp, err := rowStream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read rows: %w", err)
+		}
+		decoded, err := decodeArrowBatch(resp.GetArrowRecordBatch(), schema)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode arrow batch: %w", err)
+		}
+		rows = append(rows, decoded...)
+		if maxRows > 0 && int64(len(rows)) >= maxRows {
+			return rows[:maxRows], nil
+		}
+	}
+	return rows, nil
+}
+
+// decodeArrowBatch decodes a single Arrow IPC record batch (as streamed by
+// the Storage Read API) into row maps keyed by the table's field names,
+// preserving the order Storage Read API returns columns in.
+func decodeArrowBatch(batch *storagepb.ArrowRecordBatch, schema []fieldSchema) ([]map[string]any, error) {
+	if batch == nil {
+		return nil, nil
+	}
+
+	reader, err := ipc.NewReader(bytes.NewReader(batch.GetSerializedRecordBatch()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open arrow stream: %w", err)
+	}
+	defer reader.Release()
+
+	rows := []map[string]any{}
+	for {
+		rec, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		for rowIdx := 0; rowIdx < int(rec.NumRows()); rowIdx++ {
+			row := make(map[string]any, len(schema))
+			for colIdx, col := range schema {
+				if colIdx >= int(rec.NumCols()) {
+					continue
+				}
+				row[col.Name] = rec.Column(colIdx).GetOneForMarshal(rowIdx)
+			}
+			rows = append(rows, row)
+		}
+	}
+	return rows, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claimsMap map[string]map[string]any) (parameters.ParamValues, error) {
+	return parameters.ParamValues{}, nil
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
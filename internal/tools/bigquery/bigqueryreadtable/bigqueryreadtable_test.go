@@ -0,0 +1,110 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigqueryreadtable_test
+
+import (
+	"testing"
+
+	"github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools/bigquery/bigqueryreadtable"
+)
+
+func TestParseFromYamlBigQueryReadTable(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		in   string
+		want server.ToolConfigs
+	}{
+		{
+			desc: "basic example",
+			in: `
+			tools:
+				example_tool:
+					kind: bigquery-read-table
+					source: my-instance
+					description: some description
+					project: my-project
+					dataset: my_dataset
+					table: my_table
+			`,
+			want: server.ToolConfigs{
+				"example_tool": bigqueryreadtable.Config{
+					Name:         "example_tool",
+					Kind:         "bigquery-read-table",
+					Source:       "my-instance",
+					Description:  "some description",
+					AuthRequired: []string{},
+					Project:      "my-project",
+					Dataset:      "my_dataset",
+					Table:        "my_table",
+				},
+			},
+		},
+		{
+			desc: "with selected fields, row restriction and max rows",
+			in: `
+			tools:
+				example_tool:
+					kind: bigquery-read-table
+					source: my-instance
+					description: some description
+					project: my-project
+					dataset: my_dataset
+					table: my_table
+					selectedFields:
+						- id
+						- name
+					rowRestriction: "id > 10"
+					maxRows: 50
+			`,
+			want: server.ToolConfigs{
+				"example_tool": bigqueryreadtable.Config{
+					Name:           "example_tool",
+					Kind:           "bigquery-read-table",
+					Source:         "my-instance",
+					Description:    "some description",
+					AuthRequired:   []string{},
+					Project:        "my-project",
+					Dataset:        "my_dataset",
+					Table:          "my_table",
+					SelectedFields: []string{"id", "name"},
+					RowRestriction: "id > 10",
+					MaxRows:        50,
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got := struct {
+				Tools server.ToolConfigs `yaml:"tools"`
+			}{}
+			err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(tc.in), &got)
+			if err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.Tools); diff != "" {
+				t.Fatalf("incorrect parse: diff %v", diff)
+			}
+		})
+	}
+}
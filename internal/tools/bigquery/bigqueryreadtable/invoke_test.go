@@ -0,0 +1,231 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigqueryreadtable
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/bigquery"
+	storage "cloud.google.com/go/bigquery/storage/apiv1"
+	"cloud.google.com/go/bigquery/storage/apiv1/storagepb"
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	bigqueryapi "google.golang.org/api/bigquery/v2"
+	"google.golang.org/api/option"
+	http_testing "google.golang.org/api/transport/http/testing"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+)
+
+// fakeReadServer is a minimal in-memory Storage Read API server that always
+// hands back the same single Arrow record batch on one stream.
+type fakeReadServer struct {
+	storagepb.UnimplementedBigQueryReadServer
+	recordBatch []byte
+}
+
+func (f *fakeReadServer) CreateReadSession(ctx context.Context, req *storagepb.CreateReadSessionRequest) (*storagepb.ReadSession, error) {
+	return &storagepb.ReadSession{
+		Name:    "projects/test-project/locations/US/sessions/session1",
+		Streams: []*storagepb.ReadStream{{Name: "stream1"}},
+	}, nil
+}
+
+func (f *fakeReadServer) ReadRows(req *storagepb.ReadRowsRequest, stream storagepb.BigQueryRead_ReadRowsServer) error {
+	return stream.Send(&storagepb.ReadRowsResponse{
+		Rows: &storagepb.ReadRowsResponse_ArrowRecordBatch{
+			ArrowRecordBatch: &storagepb.ArrowRecordBatch{SerializedRecordBatch: f.recordBatch},
+		},
+	})
+}
+
+// arrowBatch serializes a single-column, two-row Arrow IPC record batch of
+// int64s, matching the shape the Storage Read API streams back in ARROW
+// mode.
+func arrowBatch(t *testing.T) []byte {
+	t.Helper()
+	pool := memory.NewGoAllocator()
+	schema := arrow.NewSchema([]arrow.Field{{Name: "id", Type: arrow.PrimitiveTypes.Int64}}, nil)
+
+	bldr := array.NewRecordBuilder(pool, schema)
+	defer bldr.Release()
+	bldr.Field(0).(*array.Int64Builder).AppendValues([]int64{1, 2}, nil)
+	rec := bldr.NewRecord()
+	defer rec.Release()
+
+	var buf bytes.Buffer
+	writer := ipc.NewWriter(&buf, ipc.WithSchema(schema))
+	if err := writer.Write(rec); err != nil {
+		t.Fatalf("ipc writer.Write: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("ipc writer.Close: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// newFakeReadClientFactory starts an in-process gRPC server implementing
+// the Storage Read API and returns a newReadClient func dialed against it,
+// so Invoke can be exercised without a real Storage Read API backend.
+func newFakeReadClientFactory(t *testing.T, srv *fakeReadServer) func(ctx context.Context, opts ...option.ClientOption) (*storage.BigQueryReadClient, error) {
+	t.Helper()
+
+	lis := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer()
+	storagepb.RegisterBigQueryReadServer(grpcServer, srv)
+	go grpcServer.Serve(lis)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("grpc.NewClient: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return func(ctx context.Context, opts ...option.ClientOption) (*storage.BigQueryReadClient, error) {
+		return storage.NewBigQueryReadClient(ctx, option.WithGRPCConn(conn))
+	}
+}
+
+func newMockRestService(t *testing.T, body string) *bigqueryapi.Service {
+	t.Helper()
+	mockTransport, err := http_testing.NewRoundTripper(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}, nil
+	})
+	if err != nil {
+		t.Fatalf("http_testing.NewRoundTripper: %v", err)
+	}
+	restService, err := bigqueryapi.NewService(context.Background(), option.WithHTTPClient(&http.Client{Transport: mockTransport}))
+	if err != nil {
+		t.Fatalf("bigqueryapi.NewService: %v", err)
+	}
+	return restService
+}
+
+const tableGetResponse = `{
+	"schema": {"fields": [{"name":"id","type":"INTEGER","mode":"REQUIRED"}]}
+}`
+
+func TestInvoke(t *testing.T) {
+	restService := newMockRestService(t, tableGetResponse)
+	readClientFactory := newFakeReadClientFactory(t, &fakeReadServer{recordBatch: arrowBatch(t)})
+
+	tool := Tool{
+		Name:          "test-tool",
+		Kind:          kind,
+		Project:       "test-project",
+		Dataset:       "test-dataset",
+		Table:         "test-table",
+		restService:   restService,
+		newReadClient: readClientFactory,
+	}
+
+	result, err := tool.Invoke(context.Background(), parameters.ParamValues{}, "")
+	if err != nil {
+		t.Fatalf("Invoke() error = %v", err)
+	}
+
+	got, ok := result.(readTableResult)
+	if !ok {
+		t.Fatalf("Invoke() returned %T, want readTableResult", result)
+	}
+	if len(got.Schema) != 1 || got.Schema[0].Name != "id" {
+		t.Fatalf("Invoke() schema = %+v, want a single 'id' field", got.Schema)
+	}
+	if len(got.Rows) != 2 {
+		t.Fatalf("Invoke() returned %d rows, want 2", len(got.Rows))
+	}
+	for i, want := range []int64{1, 2} {
+		if got.Rows[i]["id"] != want {
+			t.Errorf("row %d id = %v, want %v", i, got.Rows[i]["id"], want)
+		}
+	}
+}
+
+func TestInvokeMaxRows(t *testing.T) {
+	restService := newMockRestService(t, tableGetResponse)
+	readClientFactory := newFakeReadClientFactory(t, &fakeReadServer{recordBatch: arrowBatch(t)})
+
+	tool := Tool{
+		Name:          "test-tool",
+		Kind:          kind,
+		Project:       "test-project",
+		Dataset:       "test-dataset",
+		Table:         "test-table",
+		MaxRows:       1,
+		restService:   restService,
+		newReadClient: readClientFactory,
+	}
+
+	result, err := tool.Invoke(context.Background(), parameters.ParamValues{}, "")
+	if err != nil {
+		t.Fatalf("Invoke() error = %v", err)
+	}
+	got, ok := result.(readTableResult)
+	if !ok {
+		t.Fatalf("Invoke() returned %T, want readTableResult", result)
+	}
+	if len(got.Rows) != 1 {
+		t.Fatalf("Invoke() returned %d rows, want 1 (MaxRows should cap the stream)", len(got.Rows))
+	}
+}
+
+func TestInitializeRejectsDisallowedDataset(t *testing.T) {
+	cfg := Config{
+		Name:        "test-tool",
+		Kind:        kind,
+		Source:      "my-instance",
+		Description: "test tool",
+		Project:     "test-project",
+		Dataset:     "secret_dataset",
+		Table:       "test-table",
+	}
+	srcs := map[string]sources.Source{
+		"my-instance": &disallowAllSource{},
+	}
+	if _, err := cfg.Initialize(srcs); err == nil {
+		t.Fatal("Initialize() error = nil, want an error for a disallowed dataset")
+	}
+}
+
+type disallowAllSource struct {
+	sources.Source
+}
+
+func (s *disallowAllSource) BigQueryClient() *bigquery.Client          { return nil }
+func (s *disallowAllSource) BigQueryRestService() *bigqueryapi.Service { return nil }
+func (s *disallowAllSource) GetMaxQueryResultRows() int                { return 0 }
+func (s *disallowAllSource) IsDatasetAllowed(projectID, datasetID string) bool {
+	return false
+}
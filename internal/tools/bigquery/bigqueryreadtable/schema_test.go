@@ -0,0 +1,69 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigqueryreadtable
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestResolveStreamSchema(t *testing.T) {
+	tableSchema := []fieldSchema{
+		{Name: "id", Type: "INTEGER", Mode: "REQUIRED"},
+		{Name: "name", Type: "STRING", Mode: "NULLABLE"},
+		{Name: "created_at", Type: "TIMESTAMP", Mode: "NULLABLE"},
+	}
+
+	tcs := []struct {
+		desc           string
+		selectedFields []string
+		want           []fieldSchema
+		wantErr        bool
+	}{
+		{
+			desc:           "no selected fields returns full table schema",
+			selectedFields: nil,
+			want:           tableSchema,
+		},
+		{
+			desc:           "narrows and reorders to match the stream",
+			selectedFields: []string{"name", "id"},
+			want: []fieldSchema{
+				{Name: "name", Type: "STRING", Mode: "NULLABLE"},
+				{Name: "id", Type: "INTEGER", Mode: "REQUIRED"},
+			},
+		},
+		{
+			desc:           "unknown selected field errors",
+			selectedFields: []string{"does_not_exist"},
+			wantErr:        true,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := resolveStreamSchema(tableSchema, tc.selectedFields)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("resolveStreamSchema() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect schema: diff %v", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,493 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bigquerysqlgateway implements a single tool that proxies arbitrary
+// query/exec/transaction requests to BigQuery, so an agent can plan against
+// one tool instead of one pre-declared tool per canned query. It mirrors the
+// db-connect pattern: one endpoint, caller-supplied sql, source-side auth
+// and allow-listing enforced on every request.
+package bigquerysqlgateway
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/goccy/go-yaml"
+	"google.golang.org/api/iterator"
+
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	bigqueryds "github.com/googleapis/genai-toolbox/internal/sources/bigquery"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+)
+
+const kind string = "bigquery-sql-gateway"
+
+func init() {
+	if !tools.Register(kind, newConfig) {
+		panic(fmt.Sprintf("tool kind %q already registered", kind))
+	}
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
+// Config is the configuration for the bigquery-sql-gateway tool.
+type Config struct {
+	Name         string   `yaml:"name" validate:"required"`
+	Kind         string   `yaml:"kind" validate:"required"`
+	Source       string   `yaml:"source" validate:"required"`
+	Description  string   `yaml:"description" validate:"required"`
+	AuthRequired []string `yaml:"authRequired"`
+}
+
+// ToolConfigKind returns the kind of tool this config builds.
+func (cfg Config) ToolConfigKind() string {
+	return kind
+}
+
+// compatibleSource is the subset of the BigQuery source this tool relies on.
+type compatibleSource interface {
+	BigQueryClient() *bigquery.Client
+	BigQueryClientCreator() bigqueryds.BigqueryClientCreator
+	BigQueryWriteMode() string
+	UseClientAuthorization() bool
+	IsDatasetAllowed(projectID, datasetID string) bool
+	GetMaxQueryResultRows() int
+}
+
+// Initialize returns a Tool that gateways query/exec/transaction requests to
+// the configured BigQuery source.
+func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
+	rawS, ok := srcs[cfg.Source]
+	if !ok {
+		return nil, fmt.Errorf("no source named %q configured", cfg.Source)
+	}
+
+	s, ok := rawS.(compatibleSource)
+	if !ok {
+		return nil, fmt.Errorf("source %q does not support bigquery-sql-gateway", cfg.Source)
+	}
+
+	allParameters := parameters.Parameters{
+		parameters.NewStringParameter("sql", "The sql statement to run."),
+		parameters.NewArrayParameterWithDefault("params", []any{}, "Positional (?) or named (@name) query parameters to bind into sql.",
+			parameters.NewMapParameter("", "A single query parameter.", "string")),
+		parameters.NewStringParameterWithDefault("mode", "query", "One of \"query\", \"exec\" or \"transaction\"."),
+		parameters.NewArrayParameterWithDefault("statements", []any{}, "The sql statements to run, in order, when mode is \"transaction\".",
+			parameters.NewStringParameter("", "A single sql statement.")),
+	}
+
+	mcpManifest := tools.GetMcpManifest(cfg.Name, cfg.Description, cfg.AuthRequired, allParameters)
+
+	return Tool{
+		Name:               cfg.Name,
+		Kind:               kind,
+		AuthRequired:       cfg.AuthRequired,
+		AllParams:          allParameters,
+		client:             s.BigQueryClient(),
+		clientCreator:      s.BigQueryClientCreator(),
+		writeMode:          s.BigQueryWriteMode(),
+		useClientAuthz:     s.UseClientAuthorization(),
+		isDatasetAllowed:   s.IsDatasetAllowed,
+		maxQueryResultRows: s.GetMaxQueryResultRows(),
+		manifest:           tools.Manifest{Description: cfg.Description, Parameters: allParameters.Manifest(), AuthRequired: cfg.AuthRequired},
+		mcpManifest:        mcpManifest,
+	}, nil
+}
+
+// Tool is the bigquery-sql-gateway tool: a single endpoint that accepts
+// arbitrary query/exec/transaction requests against one BigQuery source.
+type Tool struct {
+	Name         string `yaml:"name"`
+	Kind         string `yaml:"kind"`
+	AuthRequired []string
+	AllParams    parameters.Parameters
+
+	client             *bigquery.Client
+	clientCreator      bigqueryds.BigqueryClientCreator
+	writeMode          string
+	useClientAuthz     bool
+	isDatasetAllowed   func(projectID, datasetID string) bool
+	maxQueryResultRows int
+	manifest           tools.Manifest
+	mcpManifest        tools.McpManifest
+}
+
+// gatewayResult is the response shape for every mode: the columns and rows
+// produced, plus the job stats BigQuery reported for the request.
+type gatewayResult struct {
+	Columns []string         `json:"columns"`
+	Rows    []map[string]any `json:"rows"`
+	Stats   jobStats         `json:"stats"`
+}
+
+type jobStats struct {
+	TotalBytesProcessed int64 `json:"totalBytesProcessed"`
+	TotalBytesBilled    int64 `json:"totalBytesBilled"`
+}
+
+// Invoke resolves the client to run as (the caller's own OAuth token when
+// the source has UseClientAuthorization enabled, otherwise the source's own
+// credentials), preflights every statement under that same client to learn
+// its real statement type and referenced tables, rejects writes on a
+// blocked source and tables outside the source's dataset allow-list, and
+// then executes the request in the requested mode.
+func (t Tool) Invoke(ctx context.Context, params parameters.ParamValues, accessToken string) (any, error) {
+	paramsMap := params.AsMap()
+
+	mode, _ := paramsMap["mode"].(string)
+	if mode == "" {
+		mode = "query"
+	}
+
+	client := t.client
+	if t.useClientAuthz {
+		if t.clientCreator == nil {
+			return nil, fmt.Errorf("tool %q: source requires client-side authorization but has no client creator configured", t.Name)
+		}
+		c, _, err := t.clientCreator(accessToken, false)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create client for caller's credentials: %w", err)
+		}
+		client = c
+	}
+
+	statements, err := statementsForMode(mode, paramsMap)
+	if err != nil {
+		return nil, err
+	}
+
+	queryParams, err := parseQueryParams(paramsMap["params"])
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse params: %w", err)
+	}
+	bqParams, err := toBigQueryParameters(queryParams)
+	if err != nil {
+		return nil, fmt.Errorf("unable to bind params: %w", err)
+	}
+
+	for _, stmt := range statements {
+		if err := t.preflight(ctx, client, stmt, bqParams); err != nil {
+			return nil, err
+		}
+	}
+
+	if mode == "transaction" {
+		return t.runTransaction(ctx, client, statements, bqParams)
+	}
+
+	var result gatewayResult
+	for _, stmt := range statements {
+		query := client.Query(stmt)
+		query.Parameters = bqParams
+		job, err := query.Run(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to run statement: %w", err)
+		}
+		it, err := job.Read(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read results: %w", err)
+		}
+		rows, cols, err := readGatewayRows(it, t.maxQueryResultRows)
+		if err != nil {
+			return nil, err
+		}
+		result.Columns = cols
+		result.Rows = append(result.Rows, rows...)
+		if status, err := job.Status(ctx); err == nil {
+			if qStats, ok := status.Statistics.Details.(*bigquery.QueryStatistics); ok {
+				result.Stats.TotalBytesProcessed += qStats.TotalBytesProcessed
+				result.Stats.TotalBytesBilled += qStats.TotalBytesBilled
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// runTransaction wraps statements in a single BigQuery script guarded by
+// BEGIN TRANSACTION/COMMIT TRANSACTION and runs it as one jobs.insert, so
+// mode "transaction" gets the atomicity its name implies: if any statement
+// fails, BigQuery rolls back the ones that already ran in the same script,
+// rather than leaving earlier statements' effects standing the way running
+// each one as an independent client.Query call would.
+func (t Tool) runTransaction(ctx context.Context, client *bigquery.Client, statements []string, bqParams []bigquery.QueryParameter) (any, error) {
+	script := "BEGIN TRANSACTION;\n" + strings.Join(statements, ";\n") + ";\nCOMMIT TRANSACTION;"
+
+	query := client.Query(script)
+	query.Parameters = bqParams
+
+	job, err := query.Run(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to run transaction: %w", err)
+	}
+	status, err := job.Wait(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to wait for transaction: %w", err)
+	}
+	if err := status.Err(); err != nil {
+		return nil, fmt.Errorf("transaction failed: %w", err)
+	}
+
+	scriptJob, err := client.JobFromProject(ctx, job.ProjectID(), job.ID(), job.Location())
+	if err != nil {
+		return nil, fmt.Errorf("unable to look up transaction job: %w", err)
+	}
+
+	var result gatewayResult
+	childIt := scriptJob.Children(ctx)
+	for {
+		childJob, err := childIt.Next()
+		if err != nil {
+			break
+		}
+		it, err := childJob.Read(ctx)
+		if err != nil {
+			continue
+		}
+		rows, cols, err := readGatewayRows(it, t.maxQueryResultRows)
+		if err != nil {
+			return nil, err
+		}
+		if len(cols) > 0 {
+			result.Columns = cols
+		}
+		result.Rows = append(result.Rows, rows...)
+		if childStatus, err := childJob.Status(ctx); err == nil {
+			if qStats, ok := childStatus.Statistics.Details.(*bigquery.QueryStatistics); ok {
+				result.Stats.TotalBytesProcessed += qStats.TotalBytesProcessed
+				result.Stats.TotalBytesBilled += qStats.TotalBytesBilled
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// queryParam is a single caller-supplied BigQuery query parameter, either
+// positional (Name == "") or named.
+type queryParam struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Value any    `json:"value"`
+}
+
+func parseQueryParams(raw any) ([]queryParam, error) {
+	if raw == nil {
+		return nil, nil
+	}
+	rawList, ok := raw.([]any)
+	if !ok {
+		return nil, fmt.Errorf("expected an array")
+	}
+	out := make([]queryParam, 0, len(rawList))
+	for _, rawItem := range rawList {
+		m, ok := rawItem.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("expected each param to be an object with name, type and value")
+		}
+		p := queryParam{Value: m["value"]}
+		if name, ok := m["name"].(string); ok {
+			p.Name = name
+		}
+		if typ, ok := m["type"].(string); ok {
+			p.Type = typ
+		}
+		out = append(out, p)
+	}
+	return out, nil
+}
+
+func toBigQueryParameters(queryParams []queryParam) ([]bigquery.QueryParameter, error) {
+	out := make([]bigquery.QueryParameter, 0, len(queryParams))
+	for _, p := range queryParams {
+		value, err := coerceParamValue(p)
+		if err != nil {
+			return nil, fmt.Errorf("param %q: %w", p.Name, err)
+		}
+		out = append(out, bigquery.QueryParameter{Name: p.Name, Value: value})
+	}
+	return out, nil
+}
+
+// coerceParamValue converts a caller-supplied param into the Go value
+// bigquery.QueryParameter expects for the declared BQ type, since values
+// decoded from JSON/YAML are ambiguous on their own (e.g. a JSON number
+// always decodes to float64, even when the caller declared INT64).
+func coerceParamValue(p queryParam) (any, error) {
+	if p.Type == "" {
+		return p.Value, nil
+	}
+	switch strings.ToUpper(p.Type) {
+	case "INT64", "INTEGER":
+		switch v := p.Value.(type) {
+		case float64:
+			return int64(v), nil
+		case int64:
+			return v, nil
+		case string:
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid INT64 value %v: %w", p.Value, err)
+			}
+			return n, nil
+		default:
+			return nil, fmt.Errorf("invalid INT64 value %v", p.Value)
+		}
+	case "FLOAT64", "FLOAT":
+		switch v := p.Value.(type) {
+		case float64:
+			return v, nil
+		case string:
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid FLOAT64 value %v: %w", p.Value, err)
+			}
+			return f, nil
+		default:
+			return nil, fmt.Errorf("invalid FLOAT64 value %v", p.Value)
+		}
+	case "BOOL", "BOOLEAN":
+		if v, ok := p.Value.(bool); ok {
+			return v, nil
+		}
+		return nil, fmt.Errorf("invalid BOOL value %v", p.Value)
+	case "STRING", "DATE", "DATETIME", "TIME", "TIMESTAMP", "NUMERIC", "BIGNUMERIC":
+		if v, ok := p.Value.(string); ok {
+			return v, nil
+		}
+		return nil, fmt.Errorf("invalid %s value %v: expected a string", p.Type, p.Value)
+	default:
+		return nil, fmt.Errorf("unsupported param type %q", p.Type)
+	}
+}
+
+// statementsForMode resolves the ordered list of sql statements to run for
+// the given request mode.
+func statementsForMode(mode string, paramsMap map[string]any) ([]string, error) {
+	switch mode {
+	case "query", "exec":
+		sql, ok := paramsMap["sql"].(string)
+		if !ok || sql == "" {
+			return nil, fmt.Errorf("mode %q requires a non-empty sql statement", mode)
+		}
+		return []string{sql}, nil
+	case "transaction":
+		raw, ok := paramsMap["statements"].([]any)
+		if !ok || len(raw) == 0 {
+			return nil, fmt.Errorf("mode \"transaction\" requires a non-empty statements list")
+		}
+		statements := make([]string, 0, len(raw))
+		for _, s := range raw {
+			stmt, ok := s.(string)
+			if !ok {
+				return nil, fmt.Errorf("each transaction statement must be a string")
+			}
+			statements = append(statements, stmt)
+		}
+		return statements, nil
+	default:
+		return nil, fmt.Errorf("unsupported mode %q: expected \"query\", \"exec\" or \"transaction\"", mode)
+	}
+}
+
+// preflight dry-runs stmt under client - the same client (source or
+// per-caller) that will go on to execute it - to learn its real statement
+// type and referenced tables. It rejects the statement if the source's
+// write mode blocks non-SELECT statements, regardless of what mode the
+// caller declared, and rejects any referenced table outside the source's
+// dataset allow-list.
+func (t Tool) preflight(ctx context.Context, client *bigquery.Client, stmt string, bqParams []bigquery.QueryParameter) error {
+	query := client.Query(stmt)
+	query.DryRun = true
+	query.Parameters = bqParams
+
+	job, err := query.Run(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to preflight statement: %w", err)
+	}
+	status, err := job.Status(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to read preflight status: %w", err)
+	}
+	qStats, ok := status.Statistics.Details.(*bigquery.QueryStatistics)
+	if !ok {
+		return nil
+	}
+
+	if qStats.StatementType != "" && qStats.StatementType != "SELECT" && t.writeMode == bigqueryds.WriteModeBlocked {
+		return fmt.Errorf("tool %q: source is configured with write mode %q; statement type %q is not allowed", t.Name, t.writeMode, qStats.StatementType)
+	}
+
+	for _, table := range qStats.ReferencedTables {
+		if !t.isDatasetAllowed(table.ProjectID, table.DatasetID) {
+			return fmt.Errorf("dataset %q.%q is not allowed by the source of tool %q", table.ProjectID, table.DatasetID, t.Name)
+		}
+	}
+	return nil
+}
+
+func readGatewayRows(it *bigquery.RowIterator, maxRows int) ([]map[string]any, []string, error) {
+	var rows []map[string]any
+	var columns []string
+	for {
+		var row map[string]bigquery.Value
+		err := it.Next(&row)
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to parse row: %w", err)
+		}
+		if columns == nil {
+			for col := range row {
+				columns = append(columns, col)
+			}
+		}
+		converted := make(map[string]any, len(row))
+		for k, v := range row {
+			converted[k] = v
+		}
+		rows = append(rows, converted)
+		if maxRows > 0 && len(rows) >= maxRows {
+			break
+		}
+	}
+	return rows, columns, nil
+}
+
+func (t Tool) ParseParams(data map[string]any, claimsMap map[string]map[string]any) (parameters.ParamValues, error) {
+	return tools.ParseParams(t.AllParams, data, claimsMap)
+}
+
+func (t Tool) Manifest() tools.Manifest {
+	return t.manifest
+}
+
+func (t Tool) McpManifest() tools.McpManifest {
+	return t.mcpManifest
+}
+
+func (t Tool) Authorized(verifiedAuthServices []string) bool {
+	return tools.IsAuthorized(t.AuthRequired, verifiedAuthServices)
+}
@@ -0,0 +1,284 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bigquerysqlgateway_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/bigquery"
+	"google.golang.org/api/option"
+	http_testing "google.golang.org/api/transport/http/testing"
+
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	bigqueryds "github.com/googleapis/genai-toolbox/internal/sources/bigquery"
+	"github.com/googleapis/genai-toolbox/internal/tools/bigquery/bigquerysqlgateway"
+	"github.com/googleapis/genai-toolbox/internal/util/parameters"
+)
+
+type mockBigQuerySource struct {
+	sources.Source
+	client             *bigquery.Client
+	writeMode          string
+	useClientAuthz     bool
+	disallowedDatasets map[string]bool
+}
+
+func (s *mockBigQuerySource) BigQueryClient() *bigquery.Client { return s.client }
+func (s *mockBigQuerySource) BigQueryClientCreator() bigqueryds.BigqueryClientCreator {
+	return func(accessToken string, isAuthRequired bool) (*bigquery.Client, string, error) {
+		return s.client, "test-project", nil
+	}
+}
+func (s *mockBigQuerySource) BigQueryWriteMode() string    { return s.writeMode }
+func (s *mockBigQuerySource) UseClientAuthorization() bool { return s.useClientAuthz }
+func (s *mockBigQuerySource) IsDatasetAllowed(projectID, datasetID string) bool {
+	return !s.disallowedDatasets[datasetID]
+}
+func (s *mockBigQuerySource) GetMaxQueryResultRows() int { return 0 }
+
+// jobResponse builds the JSON job body returned for every request the
+// fake BigQuery backend sees, whether it's the dry-run preflight or the
+// statement's own execution.
+func jobResponse(statementType string, referencedTables []string) string {
+	tables := make([]string, 0, len(referencedTables))
+	for _, t := range referencedTables {
+		parts := strings.SplitN(t, ".", 2)
+		tables = append(tables, fmt.Sprintf(`{"projectId":"test-project","datasetId":%q,"tableId":%q}`, parts[0], parts[1]))
+	}
+	return fmt.Sprintf(`{
+		"jobReference": {"projectId":"test-project","jobId":"job1","location":"US"},
+		"status": {"state":"DONE"},
+		"statistics": {"query": {"statementType":%q,"referencedTables":[%s]}},
+		"jobComplete": true,
+		"schema": {"fields":[]},
+		"rows": []
+	}`, statementType, strings.Join(tables, ","))
+}
+
+func newMockClient(t *testing.T, body string) *bigquery.Client {
+	t.Helper()
+	mockTransport, err := http_testing.NewRoundTripper(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}, nil
+	})
+	if err != nil {
+		t.Fatalf("http_testing.NewRoundTripper: %v", err)
+	}
+	client, err := bigquery.NewClient(context.Background(), "test-project", option.WithHTTPClient(&http.Client{Transport: mockTransport}))
+	if err != nil {
+		t.Fatalf("bigquery.NewClient: %v", err)
+	}
+	return client
+}
+
+func TestInvoke(t *testing.T) {
+	tcs := []struct {
+		name               string
+		writeMode          string
+		useClientAuthz     bool
+		disallowedDatasets map[string]bool
+		statementType      string
+		referencedTables   []string
+		params             parameters.ParamValues
+		expectedErr        bool
+	}{
+		{
+			name:             "blocked source rejects a DML statement even with mode query",
+			writeMode:        bigqueryds.WriteModeBlocked,
+			statementType:    "DELETE",
+			referencedTables: []string{"my_dataset.my_table"},
+			params: parameters.ParamValues{
+				parameters.ParamValue{Name: "sql", Value: "DELETE FROM my_dataset.my_table WHERE true"},
+				parameters.ParamValue{Name: "mode", Value: "query"},
+			},
+			expectedErr: true,
+		},
+		{
+			name:             "blocked source allows a select",
+			writeMode:        bigqueryds.WriteModeBlocked,
+			statementType:    "SELECT",
+			referencedTables: []string{"my_dataset.my_table"},
+			params: parameters.ParamValues{
+				parameters.ParamValue{Name: "sql", Value: "SELECT * FROM my_dataset.my_table"},
+				parameters.ParamValue{Name: "mode", Value: "query"},
+			},
+			expectedErr: false,
+		},
+		{
+			name:               "disallowed dataset is rejected",
+			writeMode:          bigqueryds.WriteModeAllowed,
+			statementType:      "SELECT",
+			referencedTables:   []string{"secret_dataset.my_table"},
+			disallowedDatasets: map[string]bool{"secret_dataset": true},
+			params: parameters.ParamValues{
+				parameters.ParamValue{Name: "sql", Value: "SELECT * FROM secret_dataset.my_table"},
+				parameters.ParamValue{Name: "mode", Value: "query"},
+			},
+			expectedErr: true,
+		},
+		{
+			name:             "named query parameter is bound with its declared type",
+			writeMode:        bigqueryds.WriteModeAllowed,
+			statementType:    "SELECT",
+			referencedTables: []string{"my_dataset.my_table"},
+			params: parameters.ParamValues{
+				parameters.ParamValue{Name: "sql", Value: "SELECT * FROM my_dataset.my_table WHERE id = @id"},
+				parameters.ParamValue{Name: "mode", Value: "query"},
+				parameters.ParamValue{Name: "params", Value: []any{
+					map[string]any{"name": "id", "type": "INT64", "value": float64(42)},
+				}},
+			},
+			expectedErr: false,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			client := newMockClient(t, jobResponse(tc.statementType, tc.referencedTables))
+			defer client.Close()
+
+			srcs := map[string]sources.Source{
+				"my-instance": &mockBigQuerySource{
+					client:             client,
+					writeMode:          tc.writeMode,
+					useClientAuthz:     tc.useClientAuthz,
+					disallowedDatasets: tc.disallowedDatasets,
+				},
+			}
+
+			cfg := bigquerysqlgateway.Config{
+				Name:        "test-tool",
+				Source:      "my-instance",
+				Description: "test tool",
+			}
+			tool, err := cfg.Initialize(srcs)
+			if err != nil {
+				t.Fatalf("Initialize() error = %v", err)
+			}
+
+			_, err = tool.Invoke(context.Background(), tc.params, "")
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("Invoke() error = %v, wantErr %v", err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+// transactionJobsResponse serves a fake BigQuery backend for mode
+// "transaction": each statement's dry-run preflight (identified by
+// "dryRun":true in the request body) gets its own one-shot SELECT job, and
+// the single real jobs.insert below it (the wrapped BEGIN/COMMIT
+// TRANSACTION script) is polled to DONE, then its two child statement jobs
+// are listed and read - so the test can assert the transaction ran as one
+// script with two distinct result sets, rather than as two independent,
+// non-atomic statements.
+func transactionJobsResponse(t *testing.T, req *http.Request) (*http.Response, error) {
+	t.Helper()
+
+	jsonResponse := func(body string) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}, nil
+	}
+
+	switch {
+	case req.Method == http.MethodGet && strings.Contains(req.URL.Path, "/queries/child-job-1"):
+		return jsonResponse(`{"jobComplete":true,"schema":{"fields":[{"name":"f0_","type":"INTEGER","mode":"NULLABLE"}]},"rows":[{"f":[{"v":"1"}]}]}`)
+	case req.Method == http.MethodGet && strings.Contains(req.URL.Path, "/queries/child-job-2"):
+		return jsonResponse(`{"jobComplete":true,"schema":{"fields":[{"name":"f0_","type":"INTEGER","mode":"NULLABLE"}]},"rows":[{"f":[{"v":"2"}]}]}`)
+	case req.Method == http.MethodGet && strings.HasSuffix(req.URL.Path, "/jobs") && req.URL.Query().Get("parentJobId") == "txn-job":
+		return jsonResponse(`{"jobs":[
+			{"id":"test-project:US.child-job-1","jobReference":{"projectId":"test-project","jobId":"child-job-1","location":"US"},"state":"DONE","status":{"state":"DONE"}},
+			{"id":"test-project:US.child-job-2","jobReference":{"projectId":"test-project","jobId":"child-job-2","location":"US"},"state":"DONE","status":{"state":"DONE"}}
+		]}`)
+	case req.Method == http.MethodGet && strings.Contains(req.URL.Path, "/jobs/"):
+		return jsonResponse(`{"status":{"state":"DONE"},"jobReference":{"projectId":"test-project","jobId":"txn-job","location":"US"}}`)
+	case req.Method == http.MethodPost && strings.HasSuffix(req.URL.Path, "/jobs"):
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			t.Fatalf("read request body: %v", err)
+		}
+		if strings.Contains(string(body), `"dryRun":true`) {
+			return jsonResponse(`{"status":{"state":"DONE"},"jobReference":{"projectId":"test-project","jobId":"preflight-job","location":"US"},"statistics":{"query":{"statementType":"SELECT","referencedTables":[]}}}`)
+		}
+		return jsonResponse(`{"status":{"state":"DONE"},"jobReference":{"projectId":"test-project","jobId":"txn-job","location":"US"}}`)
+	default:
+		return jsonResponse(`{"status":{"state":"DONE"},"jobReference":{"projectId":"test-project","jobId":"txn-job","location":"US"}}`)
+	}
+}
+
+func TestInvokeTransactionMode(t *testing.T) {
+	mockTransport, err := http_testing.NewRoundTripper(func(req *http.Request) (*http.Response, error) {
+		return transactionJobsResponse(t, req)
+	})
+	if err != nil {
+		t.Fatalf("http_testing.NewRoundTripper: %v", err)
+	}
+	client, err := bigquery.NewClient(context.Background(), "test-project", option.WithHTTPClient(&http.Client{Transport: mockTransport}))
+	if err != nil {
+		t.Fatalf("bigquery.NewClient: %v", err)
+	}
+	defer client.Close()
+
+	srcs := map[string]sources.Source{
+		"my-instance": &mockBigQuerySource{client: client, writeMode: bigqueryds.WriteModeAllowed},
+	}
+
+	cfg := bigquerysqlgateway.Config{
+		Name:        "test-tool",
+		Source:      "my-instance",
+		Description: "test tool",
+	}
+	tool, err := cfg.Initialize(srcs)
+	if err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+
+	params := parameters.ParamValues{
+		parameters.ParamValue{Name: "mode", Value: "transaction"},
+		parameters.ParamValue{Name: "statements", Value: []any{"SELECT 1", "SELECT 2"}},
+	}
+	result, err := tool.Invoke(context.Background(), params, "")
+	if err != nil {
+		t.Fatalf("Invoke() error = %v", err)
+	}
+
+	// gatewayResult is unexported; round-trip through its json tags instead
+	// of reaching into the struct directly.
+	raw, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("json.Marshal(result): %v", err)
+	}
+	var got struct {
+		Rows []map[string]any `json:"rows"`
+	}
+	if err := json.Unmarshal(raw, &got); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+	if len(got.Rows) != 2 {
+		t.Fatalf("Invoke() returned %d rows across both transaction statements, want 2: %v", len(got.Rows), got.Rows)
+	}
+}